@@ -0,0 +1,172 @@
+package api
+
+import (
+	"crypto/subtle"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// middleware wraps a handler with cross-cutting behavior (logging, panic
+// recovery, rate limiting, CORS); chain composes a list of them around a
+// handler, applied in order so the first middleware runs outermost.
+type middleware func(http.Handler) http.Handler
+
+func chain(h http.Handler, mws ...middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// withLogging logs each request's method, path, status, and duration.
+func withLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.Path, sw.status, time.Since(start))
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+// withRecovery turns a panicking handler into a 500 instead of taking down
+// the server.
+func withRecovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Printf("panic handling %s %s: %v", r.Method, r.URL.Path, rec)
+				writeProblem(w, http.StatusInternalServerError, "internal server error")
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withCORS allows any origin to read the JSON API, since it's meant to be
+// called from browser-based clients that aren't same-origin with the
+// HTML server.
+func withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withAdminToken requires the caller to present token on the
+// X-Admin-Token header via a constant-time comparison. If token is empty
+// (no admin token configured), every request is rejected rather than
+// silently let through, since there's nothing safe to compare against.
+func withAdminToken(token string) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) != 1 {
+				writeProblem(w, http.StatusUnauthorized, "admin token required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ipRateLimiter grants each client IP a token-bucket budget, so one caller
+// hammering the API can't starve the rest.
+type ipRateLimiter struct {
+	ratePerSecond float64
+	burst         float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastEvict time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+func newIPRateLimiter(ratePerSecond, burst float64) *ipRateLimiter {
+	return &ipRateLimiter{ratePerSecond: ratePerSecond, burst: burst, buckets: make(map[string]*bucket)}
+}
+
+// bucketIdleTTL is how long an IP's bucket sits unused before allow() sweeps
+// it out, so a long-running server doesn't accumulate one bucket per
+// distinct caller forever. evictIdle is only run once per interval, rather
+// than on every request, so the sweep's O(n) scan doesn't sit on the hot
+// path of every call to allow.
+const bucketIdleTTL = 10 * time.Minute
+
+func (l *ipRateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastEvict) > bucketIdleTTL {
+		l.evictIdle(now)
+		l.lastEvict = now
+	}
+
+	b, ok := l.buckets[ip]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastFill: now}
+		l.buckets[ip] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.lastFill).Seconds()*l.ratePerSecond)
+	b.lastFill = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// evictIdle drops buckets that have been unused for bucketIdleTTL. Called
+// with l.mu held.
+func (l *ipRateLimiter) evictIdle(now time.Time) {
+	for ip, b := range l.buckets {
+		if now.Sub(b.lastFill) > bucketIdleTTL {
+			delete(l.buckets, ip)
+		}
+	}
+}
+
+// withRateLimit rejects requests once a client IP exceeds limiter's budget.
+func withRateLimit(limiter *ipRateLimiter) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r)
+			if !limiter.allow(ip) {
+				writeProblem(w, http.StatusTooManyRequests, "rate limit exceeded, try again shortly")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIPRateLimiterAllowsBurstThenBlocks(t *testing.T) {
+	l := newIPRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		if !l.allow("1.2.3.4") {
+			t.Fatalf("allow() #%d = false, want true within burst of 3", i)
+		}
+	}
+	if l.allow("1.2.3.4") {
+		t.Fatalf("allow() after exhausting burst = true, want false")
+	}
+}
+
+func TestIPRateLimiterTracksIPsSeparately(t *testing.T) {
+	l := newIPRateLimiter(1, 1)
+
+	if !l.allow("1.2.3.4") {
+		t.Fatalf("allow(1.2.3.4) = false, want true")
+	}
+	if l.allow("1.2.3.4") {
+		t.Fatalf("second allow(1.2.3.4) = true, want false (burst of 1 exhausted)")
+	}
+	if !l.allow("5.6.7.8") {
+		t.Fatalf("allow(5.6.7.8) = false, want true (separate bucket from 1.2.3.4)")
+	}
+}
+
+func TestIPRateLimiterRefillsOverTime(t *testing.T) {
+	l := newIPRateLimiter(1, 1)
+
+	if !l.allow("1.2.3.4") {
+		t.Fatalf("allow() = false, want true")
+	}
+	if l.allow("1.2.3.4") {
+		t.Fatalf("allow() immediately after exhausting burst = true, want false")
+	}
+
+	// Pretend the bucket was last filled over a second ago, so it's had time
+	// to refill at least one token.
+	l.mu.Lock()
+	l.buckets["1.2.3.4"].lastFill = time.Now().Add(-2 * time.Second)
+	l.mu.Unlock()
+
+	if !l.allow("1.2.3.4") {
+		t.Fatalf("allow() after refill window = false, want true")
+	}
+}
+
+func TestIPRateLimiterEvictsIdleBuckets(t *testing.T) {
+	l := newIPRateLimiter(1, 1)
+	l.allow("1.2.3.4")
+
+	l.mu.Lock()
+	l.buckets["1.2.3.4"].lastFill = time.Now().Add(-2 * bucketIdleTTL)
+	l.lastEvict = time.Now().Add(-2 * bucketIdleTTL)
+	l.mu.Unlock()
+
+	// Triggers the idle sweep as a side effect of allow().
+	l.allow("5.6.7.8")
+
+	l.mu.Lock()
+	_, stillThere := l.buckets["1.2.3.4"]
+	l.mu.Unlock()
+	if stillThere {
+		t.Fatalf("bucket for 1.2.3.4 survived an idle sweep, want evicted")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		remoteAddr string
+		want       string
+	}{
+		{"1.2.3.4:5678", "1.2.3.4"},
+		{"[::1]:5678", "::1"},
+		{"not-a-host-port", "not-a-host-port"},
+	}
+	for _, c := range cases {
+		r := &http.Request{RemoteAddr: c.remoteAddr}
+		if got := clientIP(r); got != c.want {
+			t.Errorf("clientIP(%q) = %q, want %q", c.remoteAddr, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package api implements the JSON REST surface under /api/v1/, alongside
+// the HTML handlers in package main, both backed by the same service.Service
+// so the two never drift out of sync. The surface is documented by the
+// OpenAPI 3 spec at openapi/movie-recommender.yaml.
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"movie-recommender/go-client/service"
+)
+
+const (
+	defaultSimilarLimit = 5
+	maxSimilarLimit     = 100
+)
+
+// NewRouter returns an http.Handler serving /api/v1/ against svc, wrapped
+// with request logging, panic recovery, and per-IP rate limiting. The
+// read-only movie routes also get CORS, since they're meant to be called
+// from browser-based clients that aren't same-origin with the HTML server;
+// POST /api/v1/index/rebuild is not, and additionally requires adminToken
+// on the X-Admin-Token header, same as the HTML server's /admin/queue*
+// routes.
+func NewRouter(svc *service.Service, adminToken string) http.Handler {
+	mux := http.NewServeMux()
+
+	h := &handlers{svc: svc}
+	limiter := newIPRateLimiter(10, 20)
+	public := func(next http.HandlerFunc) http.Handler {
+		return chain(next, withLogging, withRecovery, withCORS, withRateLimit(limiter))
+	}
+	admin := func(next http.HandlerFunc) http.Handler {
+		return chain(next, withLogging, withRecovery, withRateLimit(limiter), withAdminToken(adminToken))
+	}
+
+	mux.Handle("GET /api/v1/movies/search", public(h.search))
+	mux.Handle("GET /api/v1/movies/{id}", public(h.movie))
+	mux.Handle("GET /api/v1/movies/{id}/similar", public(h.similar))
+	mux.Handle("POST /api/v1/index/rebuild", admin(h.rebuildIndex))
+
+	return mux
+}
+
+type handlers struct {
+	svc *service.Service
+}
+
+type movieResponse struct {
+	MovieID int32 `json:"movie_id"`
+}
+
+type recommendationResponse struct {
+	MovieID int32   `json:"movie_id"`
+	Title   string  `json:"title"`
+	Score   float32 `json:"score"`
+}
+
+// search handles GET /api/v1/movies/search?q=.
+func (h *handlers) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		writeProblem(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	movieID, err := h.svc.SearchMovieID(r.Context(), query)
+	if err != nil {
+		log.Printf("searching for %q: %v", query, err)
+		writeProblem(w, http.StatusBadGateway, "looking up movie failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, movieResponse{MovieID: movieID})
+}
+
+// movie handles GET /api/v1/movies/{id}.
+func (h *handlers) movie(w http.ResponseWriter, r *http.Request) {
+	movieID, err := parseMovieID(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	movie, err := h.svc.Movie(r.Context(), movieID)
+	if err != nil {
+		log.Printf("fetching movie %d: %v", movieID, err)
+		writeProblem(w, http.StatusBadGateway, "fetching movie failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, movie)
+}
+
+// similar handles GET /api/v1/movies/{id}/similar?limit=.
+func (h *handlers) similar(w http.ResponseWriter, r *http.Request) {
+	movieID, err := parseMovieID(r.PathValue("id"))
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit := int32(defaultSimilarLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, perr := strconv.ParseInt(raw, 10, 32)
+		if perr != nil || parsed <= 0 || parsed > maxSimilarLimit {
+			writeProblem(w, http.StatusBadRequest, "limit must be a positive integer no greater than 100")
+			return
+		}
+		limit = int32(parsed)
+	}
+
+	recs, err := h.svc.SimilarMovies(r.Context(), movieID, limit)
+	if err == service.ErrNotIndexed {
+		writeProblem(w, http.StatusAccepted, "movie isn't indexed yet; it's been queued and should be searchable again shortly")
+		return
+	}
+	if err != nil {
+		log.Printf("fetching similar movies for %d: %v", movieID, err)
+		writeProblem(w, http.StatusBadGateway, "fetching similar movies failed")
+		return
+	}
+
+	out := make([]recommendationResponse, 0, len(recs))
+	for _, rec := range recs {
+		out = append(out, recommendationResponse{MovieID: rec.MovieId, Title: rec.Title, Score: rec.Score})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// rebuildIndex handles POST /api/v1/index/rebuild.
+func (h *handlers) rebuildIndex(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.RebuildIndex(); err != nil {
+		writeProblem(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func parseMovieID(raw string) (int32, error) {
+	id, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, errInvalidMovieID
+	}
+	return int32(id), nil
+}
+
+var errInvalidMovieID = errors.New("id must be an integer")
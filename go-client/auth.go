@@ -0,0 +1,241 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"movie-recommender/go-client/service"
+	"movie-recommender/go-client/users"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const sessionCookieName = "session"
+
+// currentUser resolves the logged-in user from the session cookie, if any.
+func currentUser(r *http.Request) (*users.User, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return nil, false
+	}
+	userID, ok := sessionStore.Lookup(cookie.Value)
+	if !ok {
+		return nil, false
+	}
+	user, err := userStore.UserByID(userID)
+	if err != nil {
+		return nil, false
+	}
+	return user, true
+}
+
+func setSessionCookie(w http.ResponseWriter, userID int64) error {
+	token, err := sessionStore.Create(userID)
+	if err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Expires:  time.Now().Add(30 * 24 * time.Hour),
+	})
+	return nil
+}
+
+// handleSignup handles both the signup form (GET) and its submission (POST).
+func handleSignup(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		w.Write([]byte(`
+		<html><body><h1>Sign up</h1>
+		<form action="/signup" method="post">
+			<input type="email" name="email" placeholder="Email" /><br/>
+			<input type="password" name="password" placeholder="Password" /><br/>
+			<input type="submit" value="Sign up" />
+		</form></body></html>
+		`))
+		return
+	}
+
+	email := strings.TrimSpace(r.FormValue("email"))
+	password := r.FormValue("password")
+	if email == "" || password == "" {
+		http.Error(w, "email and password are required", http.StatusBadRequest)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("hashing password: %v", err), http.StatusInternalServerError)
+		return
+	}
+	user, err := userStore.CreateUser(email, string(hash))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("creating account: %v", err), http.StatusConflict)
+		return
+	}
+	if err := setSessionCookie(w, user.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/me", http.StatusSeeOther)
+}
+
+// handleLogin handles both the login form (GET) and its submission (POST).
+func handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		w.Write([]byte(`
+		<html><body><h1>Log in</h1>
+		<form action="/login" method="post">
+			<input type="email" name="email" placeholder="Email" /><br/>
+			<input type="password" name="password" placeholder="Password" /><br/>
+			<input type="submit" value="Log in" />
+		</form></body></html>
+		`))
+		return
+	}
+
+	email := strings.TrimSpace(r.FormValue("email"))
+	password := r.FormValue("password")
+	user, err := userStore.UserByEmail(email)
+	if err != nil {
+		http.Error(w, "invalid email or password", http.StatusUnauthorized)
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		http.Error(w, "invalid email or password", http.StatusUnauthorized)
+		return
+	}
+	if err := setSessionCookie(w, user.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/me", http.StatusSeeOther)
+}
+
+// handleLogout ends the caller's session.
+func handleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		sessionStore.Delete(cookie.Value)
+	}
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: "", Path: "/", MaxAge: -1})
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// handleMe shows a logged-in user's rating history alongside
+// recommendations personalized to their taste vector.
+func handleMe(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	history, err := svc.History(user.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("loading history: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<html><head><title>My recommendations</title></head><body>")
+	sb.WriteString(fmt.Sprintf("<h1>Welcome, %s</h1>", user.Email))
+
+	recs, err := svc.PersonalizedRecommendations(r.Context(), user.ID, 10)
+	if err == service.ErrNoTasteProfile {
+		sb.WriteString("<p>Rate a few movies to get personalized recommendations.</p>")
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("loading personalized recommendations: %v", err), http.StatusInternalServerError)
+		return
+	} else {
+		sb.WriteString("<h2>Recommended for you</h2><ul>")
+		for _, rec := range recs {
+			sb.WriteString(fmt.Sprintf(
+				`<li>%s (Movie ID: %d) `+
+					`<form style="display:inline" action="/rate" method="post">`+
+					`<input type="hidden" name="movie_id" value="%d" />`+
+					`<button name="direction" value="up">&#128077;</button>`+
+					`<button name="direction" value="down">&#128078;</button>`+
+					`</form></li>`,
+				rec.Title, rec.MovieId, rec.MovieId,
+			))
+		}
+		sb.WriteString("</ul>")
+	}
+
+	sb.WriteString("<h2>Your history</h2><ul>")
+	for _, event := range history {
+		sb.WriteString(fmt.Sprintf("<li>Movie ID %d &mdash; rating %d at %s</li>",
+			event.MovieID, event.Rating, event.CreatedAt.Format(time.RFC3339)))
+	}
+	sb.WriteString("</ul>")
+	sb.WriteString(`<a href="/logout">Log out</a>`)
+	sb.WriteString("</body></html>")
+
+	w.Write([]byte(sb.String()))
+}
+
+// handleRate records explicit thumbs-up/thumbs-down feedback on a
+// recommendation so the caller's taste vector updates on the next
+// personalized request.
+func handleRate(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(r)
+	if !ok {
+		http.Error(w, "log in to rate movies", http.StatusUnauthorized)
+		return
+	}
+
+	movieID, err := strconv.Atoi(r.FormValue("movie_id"))
+	if err != nil {
+		http.Error(w, "invalid movie_id", http.StatusBadRequest)
+		return
+	}
+
+	var rating int
+	switch r.FormValue("direction") {
+	case "up":
+		rating = users.ThumbsUpRating
+	case "down":
+		rating = users.ThumbsDownRating
+	default:
+		http.Error(w, "direction must be 'up' or 'down'", http.StatusBadRequest)
+		return
+	}
+
+	if err := svc.RecordRating(user.ID, int32(movieID), rating); err != nil {
+		http.Error(w, fmt.Sprintf("recording rating: %v", err), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/me", http.StatusSeeOther)
+}
+
+// requireAdmin wraps next so it only runs when the caller presents
+// adminToken (configured via -admin-token) on the X-Admin-Token header,
+// compared in constant time. With no token configured, every request is
+// refused rather than silently allowed through.
+func requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if adminToken == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(adminToken)) != 1 {
+			http.Error(w, "admin token required", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// recordImplicitClick logs that user viewed movieID's recommendations, a
+// weak positive signal folded into their taste vector alongside explicit
+// ratings.
+func recordImplicitClick(userID int64, movieID int32) {
+	if err := svc.RecordClick(userID, movieID); err != nil {
+		// Best-effort: a failed implicit-feedback write shouldn't fail the
+		// search request it's attached to.
+		log.Printf("recording implicit click for user %d, movie %d: %v", userID, movieID, err)
+	}
+}
@@ -0,0 +1,273 @@
+// Command worker leases jobs from the durable job queue and executes them
+// against TMDb and the embedding gRPC service, so catalog refreshes are
+// incremental and survive restarts instead of blocking the web server.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	pb "movie-recommender/go-client/pb/proto"
+	"movie-recommender/go-client/queue"
+	"movie-recommender/go-client/tmdb"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// embeddingBatchSize is how many compute_embedding jobs a worker pipelines
+// over a single AddMovieEmbeddingsStream call.
+const embeddingBatchSize = 16
+
+func main() {
+	queuePath := flag.String("queue", "jobs.db", "path to the job queue database")
+	concurrency := flag.Int("concurrency", 4, "number of worker goroutines")
+	grpcAddr := flag.String("grpc-addr", "localhost:50051", "address of the embedding gRPC service")
+	cacheDir := flag.String("tmdb-cache", "tmdb-cache", "directory to cache TMDb responses in")
+	flag.Parse()
+
+	q, err := queue.Open(*queuePath)
+	if err != nil {
+		log.Fatalf("opening job queue: %v", err)
+	}
+	defer q.Close()
+
+	conn, err := grpc.Dial(*grpcAddr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("failed to connect to gRPC server: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewEmbeddingServiceClient(conn)
+
+	tmdbClient := tmdb.NewClientFromEnv(tmdb.WithCache(tmdb.NewDiskCache(*cacheDir)))
+
+	log.Printf("worker starting with %d goroutines against queue %s", *concurrency, *queuePath)
+
+	go reapPeriodically(q)
+
+	done := make(chan struct{})
+	for i := 0; i < *concurrency; i++ {
+		go runWorker(i, q, client, tmdbClient, done)
+	}
+	<-done
+}
+
+// reapInterval is how often reapPeriodically prunes old Done/Failed jobs.
+const reapInterval = time.Hour
+
+// reapPeriodically prunes old Done/Failed jobs on a fixed interval for as
+// long as the worker runs, so the queue bucket doesn't grow without bound.
+func reapPeriodically(q queue.JobQueue) {
+	for range time.Tick(reapInterval) {
+		n, err := q.Reap()
+		if err != nil {
+			log.Printf("reaping old jobs: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("reaped %d old job(s)", n)
+		}
+	}
+}
+
+// runWorker polls the queue for runnable jobs and executes them. It never
+// returns: after a lease error or an empty poll it sleeps a second and
+// polls again. fetch_movie and
+// refresh_top_rated jobs run one at a time, but compute_embedding jobs are
+// batched and pipelined over a single AddMovieEmbeddingsStream call so a
+// full catalog refresh isn't one gRPC round-trip per movie.
+func runWorker(id int, q queue.JobQueue, client pb.EmbeddingServiceClient, tmdbClient *tmdb.Client, done chan<- struct{}) {
+	for {
+		if job, err := q.Lease(queue.FetchMovie, queue.RefreshTopRated); err != nil {
+			log.Printf("worker %d: lease error: %v", id, err)
+			time.Sleep(time.Second)
+			continue
+		} else if job != nil {
+			runJob(id, q, tmdbClient, job)
+			continue
+		}
+
+		batch := leaseBatch(q, embeddingBatchSize, queue.ComputeEmbedding)
+		if len(batch) == 0 {
+			time.Sleep(time.Second)
+			continue
+		}
+		if err := processComputeEmbeddingBatch(q, client, batch); err != nil {
+			log.Printf("worker %d: compute_embedding batch of %d failed: %v", id, len(batch), err)
+			for _, job := range batch {
+				if ferr := q.Fail(job.ID, err); ferr != nil {
+					log.Printf("worker %d: recording failure for job %s: %v", id, job.ID, ferr)
+				}
+			}
+		}
+	}
+}
+
+// leaseBatch leases up to max runnable jobs of the given types, stopping
+// early once the queue has nothing left to hand out.
+func leaseBatch(q queue.JobQueue, max int, types ...queue.Type) []*queue.Job {
+	jobs := make([]*queue.Job, 0, max)
+	for len(jobs) < max {
+		job, err := q.Lease(types...)
+		if err != nil || job == nil {
+			break
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+func runJob(id int, q queue.JobQueue, tmdbClient *tmdb.Client, job *queue.Job) {
+	var err error
+	switch job.Type {
+	case queue.FetchMovie:
+		err = processFetchMovie(q, tmdbClient, job)
+	case queue.RefreshTopRated:
+		err = processRefreshTopRated(q, tmdbClient)
+	default:
+		err = fmt.Errorf("unknown job type %q", job.Type)
+	}
+
+	if err != nil {
+		log.Printf("worker %d: job %s (%s) failed: %v", id, job.ID, job.Type, err)
+		if ferr := q.Fail(job.ID, err); ferr != nil {
+			log.Printf("worker %d: recording failure for job %s: %v", id, job.ID, ferr)
+		}
+		return
+	}
+	if cerr := q.Complete(job.ID); cerr != nil {
+		log.Printf("worker %d: marking job %s done: %v", id, job.ID, cerr)
+	}
+}
+
+func processFetchMovie(q queue.JobQueue, tmdbClient *tmdb.Client, job *queue.Job) error {
+	var payload queue.FetchMoviePayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("decoding fetch_movie payload: %w", err)
+	}
+
+	movie, err := tmdbClient.GetMovieInfo(context.Background(), payload.MovieID)
+	if err != nil {
+		return fmt.Errorf("fetching movie %d from TMDb: %w", payload.MovieID, err)
+	}
+
+	cast := make([]queue.CastMember, 0, len(movie.Cast))
+	for _, c := range movie.Cast {
+		cast = append(cast, queue.CastMember{Name: c.Name, Character: c.Character, Order: c.Order})
+	}
+
+	_, err = q.Enqueue(queue.ComputeEmbedding, queue.ComputeEmbeddingPayload{
+		MovieID:             payload.MovieID,
+		Title:               movie.Title,
+		Overview:            movie.Overview,
+		Keywords:            movie.Keywords,
+		Genres:              movie.Genres,
+		Cast:                cast,
+		ReleaseYear:         movie.ReleaseYear,
+		RuntimeMinutes:      movie.RuntimeMinutes,
+		OriginalLanguage:    movie.OriginalLanguage,
+		ProductionCountries: movie.ProductionCountries,
+		PosterPath:          movie.PosterPath,
+	})
+	return err
+}
+
+// processComputeEmbeddingBatch streams every job in the batch to the
+// embedding service over a single call and resolves each job from its own
+// ack, so one bad movie doesn't fail its neighbors.
+func processComputeEmbeddingBatch(q queue.JobQueue, client pb.EmbeddingServiceClient, batch []*queue.Job) error {
+	stream, err := client.AddMovieEmbeddingsStream(context.Background())
+	if err != nil {
+		return fmt.Errorf("opening AddMovieEmbeddingsStream: %w", err)
+	}
+
+	byMovieID := make(map[int32]*queue.Job, len(batch))
+	for _, job := range batch {
+		var payload queue.ComputeEmbeddingPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decoding compute_embedding payload for job %s: %w", job.ID, err)
+		}
+
+		cast := make([]*pb.CastMember, 0, len(payload.Cast))
+		for _, c := range payload.Cast {
+			cast = append(cast, &pb.CastMember{Name: c.Name, Character: c.Character, Order: int32(c.Order)})
+		}
+
+		byMovieID[int32(payload.MovieID)] = job
+		req := &pb.MovieRequest{
+			MovieId:             int32(payload.MovieID),
+			Title:               payload.Title,
+			Overview:            payload.Overview,
+			Keywords:            payload.Keywords,
+			Genres:              payload.Genres,
+			Cast:                cast,
+			ReleaseYear:         int32(payload.ReleaseYear),
+			RuntimeMinutes:      int32(payload.RuntimeMinutes),
+			OriginalLanguage:    payload.OriginalLanguage,
+			ProductionCountries: payload.ProductionCountries,
+			PosterPath:          payload.PosterPath,
+		}
+		if err := stream.Send(req); err != nil {
+			return fmt.Errorf("sending movie %d: %w", payload.MovieID, err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("closing AddMovieEmbeddingsStream: %w", err)
+	}
+
+	for _, ack := range resp.Acks {
+		job, ok := byMovieID[ack.MovieId]
+		if !ok {
+			continue
+		}
+		delete(byMovieID, ack.MovieId)
+		if ack.Success {
+			if err := q.Complete(job.ID); err != nil {
+				log.Printf("marking job %s done: %v", job.ID, err)
+			}
+			if err := q.MarkIndexed(int(ack.MovieId)); err != nil {
+				log.Printf("marking movie %d indexed: %v", ack.MovieId, err)
+			}
+			continue
+		}
+		if err := q.Fail(job.ID, fmt.Errorf("embedding service: %s", ack.Error)); err != nil {
+			log.Printf("recording failure for job %s: %v", job.ID, err)
+		}
+	}
+
+	// Any job with no matching ack wasn't acknowledged at all; retry it.
+	for _, job := range byMovieID {
+		if err := q.Fail(job.ID, fmt.Errorf("no ack received from embedding service")); err != nil {
+			log.Printf("recording failure for job %s: %v", job.ID, err)
+		}
+	}
+	return nil
+}
+
+// processRefreshTopRated walks TMDb's top-rated catalog and enqueues a
+// fetch_movie job for each ID that isn't already indexed, so repeated
+// rebuilds are incremental instead of re-fetching and re-embedding the
+// entire catalog every time.
+func processRefreshTopRated(q queue.JobQueue, tmdbClient *tmdb.Client) error {
+	movieIDs, err := tmdbClient.GetTopRatedMovies(context.Background())
+	if err != nil {
+		return fmt.Errorf("listing top rated movies: %w", err)
+	}
+	for _, id := range movieIDs {
+		indexed, err := q.IsIndexed(id)
+		if err != nil {
+			return fmt.Errorf("checking index status for %d: %w", id, err)
+		}
+		if indexed {
+			continue
+		}
+		if _, err := q.Enqueue(queue.FetchMovie, queue.FetchMoviePayload{MovieID: id}); err != nil {
+			return fmt.Errorf("enqueueing fetch_movie for %d: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -1,221 +1,58 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"log"
+	"movie-recommender/go-client/api"
 	pb "movie-recommender/go-client/pb/proto"
+	"movie-recommender/go-client/queue"
+	"movie-recommender/go-client/service"
+	"movie-recommender/go-client/tmdb"
+	"movie-recommender/go-client/users"
 	"net/http"
-	"net/url"
 	"os"
 	"strings"
 
 	"google.golang.org/grpc"
 )
 
-var apiKey = os.Getenv("TMDB_API_KEY")
-
-type Keyword struct {
-	ID      int    `json:"id"`
-	Keyword string `json:"name"`
-}
-
-type KeywordResponse struct {
-	ID       int       `json:"id"`
-	Keywords []Keyword `json:"keywords"`
-}
-
-type MovieSearchResponse struct {
-	Results []MovieResult `json:"results"`
-}
-
-type TopRatedResponse struct {
-	Results    []MovieResult `json:"results"`
-	TotalPages int           `json:"total_pages"`
-}
-
-type MovieResult struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	ReleaseDate string `json:"release_date"`
-}
-
-type Movie struct {
-	Title    string `json:"title"`
-	Overview string `json:"overview"`
-	Keywords string `json:"keywords"`
-}
-
-// getMovieID performs a search for a movie title on TMDb, and returns the ID of the
-// first result. If no results are found, it returns an error.
-func getMovieID(title string) (int, error) {
-	baseURL := "https://api.themoviedb.org/3/search/movie"
-	query := url.QueryEscape(title)
-	apiURL := fmt.Sprintf("%s?api_key=%s&query=%s", baseURL, apiKey, query)
-
-	resp, err := http.Get(apiURL)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, err
-	}
-
-	var searchResponse MovieSearchResponse
-	err = json.Unmarshal(body, &searchResponse)
-	if err != nil {
-		return 0, err
-	}
-
-	if len(searchResponse.Results) == 0 {
-		return 0, fmt.Errorf("no results found for movie title: %s", title)
-	}
-
-	// Return the first result's ID
-	return searchResponse.Results[0].ID, nil
-}
-
-// getJSON is a helper function that performs a GET request to the specified URL,
-// reads the response, and unmarshals the JSON into the target interface.
-func getJSON(url string, target interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Add("accept", "application/json")
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(body, target)
-}
-
-// getMovieInfo retrieves the movie information and its keywords,
-// then sets the Movie.Keywords field to the top 5 keywords joined by commas.
-func getMovieInfo(movie_id int) (Movie, error) {
-	var movie Movie
-	// Fetch movie details.
-	movieURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d?api_key=%s", movie_id, apiKey)
-	if err := getJSON(movieURL, &movie); err != nil {
-		return Movie{}, err
-	}
-
-	// Fetch keywords.
-	var keywordResp KeywordResponse
-	keywordURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d/keywords?api_key=%s", movie_id, apiKey)
-	if err := getJSON(keywordURL, &keywordResp); err != nil {
-		return movie, err
-	}
-
-	// Extract the top 5 keywords.
-	topCount := 5
-	if len(keywordResp.Keywords) < topCount {
-		topCount = len(keywordResp.Keywords)
-	}
-	topKeywords := make([]string, 0, topCount)
-	for i := 0; i < topCount; i++ {
-		topKeywords = append(topKeywords, keywordResp.Keywords[i].Keyword)
-	}
-
-	// Join the keywords into a comma-separated string.
-	movie.Keywords = strings.Join(topKeywords, ", ")
-
-	return movie, nil
-}
-
-func getTopRatedMovies() ([]int, error) {
-
-	// First, get the first page to determine the total number of pages.
-	movieURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/top_rated?api_key=%s", apiKey)
-	var topRated TopRatedResponse
-
-	if err := getJSON(movieURL, &topRated); err != nil {
-		log.Fatal(err)
-	}
-
-	fmt.Printf("Total Pages: %d\n", topRated.TotalPages)
-	// Initialize a slice to hold all movie IDs.
-	allMovieIDs := []int{}
-
-	// Process the first page.
-	for _, movie := range topRated.Results {
-		allMovieIDs = append(allMovieIDs, movie.ID)
-	}
-
-	// Now iterate through pages 2 to TotalPages.
-	for page := 2; page <= topRated.TotalPages; page++ {
-		pageURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/top_rated?api_key=%s&page=%d", apiKey, page)
-		var pageResp TopRatedResponse
-
-		if err := getJSON(pageURL, &pageResp); err != nil {
-			log.Fatalf("Error fetching page %d: %v", page, err)
-		}
+var (
+	grpcClient   pb.EmbeddingServiceClient
+	jobQueue     queue.JobQueue
+	tmdbClient   *tmdb.Client
+	userStore    users.Store
+	sessionStore *users.SessionStore
+	svc          *service.Service
+
+	// adminToken gates /admin/queue* and POST /api/v1/index/rebuild; see
+	// requireAdmin in auth.go and withAdminToken in package api.
+	adminToken string
+)
 
-		for _, movie := range pageResp.Results {
-			allMovieIDs = append(allMovieIDs, movie.ID)
-		}
+func main() {
+	// Optional flag that enqueues a refresh_top_rated job; cmd/worker then
+	// does the work incrementally, skipping movies already indexed.
+	buildFlag := flag.Bool("build", false, "Enqueue a full catalog refresh job")
+	queuePath := flag.String("queue", "jobs.db", "path to the job queue database")
+	usersPath := flag.String("users-db", "users.db", "path to the users/ratings SQLite database")
+	adminTokenFlag := flag.String("admin-token", os.Getenv("ADMIN_TOKEN"), "shared secret required via the X-Admin-Token header to call /admin/queue* or POST /api/v1/index/rebuild; if unset, those routes refuse all requests")
+	flag.Parse()
+	adminToken = *adminTokenFlag
+	if adminToken == "" {
+		log.Println("warning: -admin-token not set; /admin/queue* and POST /api/v1/index/rebuild will refuse all requests")
 	}
 
-	return allMovieIDs, nil
-}
-
-func buildIndex(client pb.EmbeddingServiceClient) error {
-	var allMovieIDs []int
-	allMovieIDs, err := getTopRatedMovies()
+	tmdbClient = tmdb.NewClientFromEnv(tmdb.WithCache(tmdb.NewMemoryCache(1024)))
 
+	us, err := users.Open(*usersPath)
 	if err != nil {
-		log.Fatal(err)
-	}
-	// Now print out all collected movie IDs.
-	fmt.Println("Collected Top Rated Movie IDs:")
-
-	for _, movie_id := range allMovieIDs {
-		movie, err := getMovieInfo(movie_id)
-
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		req := &pb.MovieRequest{
-			MovieId:  int32(movie_id),
-			Title:    movie.Title,
-			Overview: movie.Overview,
-			Keywords: movie.Keywords,
-		}
-
-		log.Printf("Sending MovieRequest: MovieId=%d, Title=%q, Overview=%q, Keywords=%q",
-			req.MovieId, req.Title, req.Overview, req.Keywords)
-
-		_, err = client.GetMovieEmbedding(context.Background(), req)
-
-		if err != nil {
-			log.Fatalf("Error calling gRPC: %v", err)
-		}
+		log.Fatalf("opening user store: %v", err)
 	}
-	return nil
-}
-
-var (
-	grpcClient pb.EmbeddingServiceClient
-)
-
-func main() {
-	// Optional flag for your existing "build the index" operation
-	buildFlag := flag.Bool("build", false, "Build the index in the vector DB")
-	flag.Parse()
+	defer us.Close()
+	userStore = us
+	sessionStore = users.NewSessionStore()
 
 	// Connect to gRPC server
 	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
@@ -226,16 +63,35 @@ func main() {
 
 	grpcClient = pb.NewEmbeddingServiceClient(conn)
 
-	// Optionally build the index (if user runs with --build)
+	q, err := queue.Open(*queuePath)
+	if err != nil {
+		log.Fatalf("opening job queue: %v", err)
+	}
+	defer q.Close()
+	jobQueue = q
+
+	svc = service.New(tmdbClient, grpcClient, jobQueue, userStore)
+
+	// Optionally kick off a full catalog refresh (if user runs with --build)
 	if *buildFlag {
-		if err := buildIndex(grpcClient); err != nil {
-			log.Fatal(err)
+		if err := svc.RebuildIndex(); err != nil {
+			log.Fatalf("enqueueing refresh_top_rated job: %v", err)
 		}
 	}
 
 	// Set up HTTP routes
 	http.HandleFunc("/", handleIndex)
 	http.HandleFunc("/search", handleSearch)
+	http.HandleFunc("/signup", handleSignup)
+	http.HandleFunc("/login", handleLogin)
+	http.HandleFunc("/logout", handleLogout)
+	http.HandleFunc("/me", handleMe)
+	http.HandleFunc("/rate", handleRate)
+	http.HandleFunc("/admin/queue", requireAdmin(handleAdminQueueDepth))
+	http.HandleFunc("/admin/queue/retry", requireAdmin(handleAdminQueueRetry))
+	http.HandleFunc("/admin/queue/pause", requireAdmin(handleAdminQueuePause))
+	http.HandleFunc("/admin/queue/resume", requireAdmin(handleAdminQueueResume))
+	http.Handle("/api/v1/", api.NewRouter(svc, adminToken))
 
 	fmt.Println("Web server running on http://localhost:8080/")
 	log.Fatal(http.ListenAndServe(":8080", nil))
@@ -252,6 +108,7 @@ func handleIndex(w http.ResponseWriter, r *http.Request) {
 				<input type="text" name="q" placeholder="Enter a movie title" />
 				<input type="submit" value="Search" />
 			</form>
+			<p><a href="/me">My recommendations</a> | <a href="/login">Log in</a> | <a href="/signup">Sign up</a></p>
 		</body>
 	</html>
 	`
@@ -260,9 +117,10 @@ func handleIndex(w http.ResponseWriter, r *http.Request) {
 
 // handleSearch handles the /search route.
 //
-// It takes the user's query from the URL, looks up the first matching TMDb movie ID,
-// calls the gRPC server to get recommendations, and then builds a simple HTML page
-// with the results.
+// It takes the user's query from the URL, resolves it to a TMDb movie ID
+// and its recommendations through service.Service, and builds a simple
+// HTML page with the results. The JSON equivalent is
+// GET /api/v1/movies/search and GET /api/v1/movies/{id}/similar.
 func handleSearch(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	if strings.TrimSpace(query) == "" {
@@ -271,27 +129,34 @@ func handleSearch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Find the first matching TMDb movie ID
-	movieID, err := getMovieID(query)
+	movieID, err := svc.SearchMovieID(r.Context(), query)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Error looking up movie: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	similarResp, err := grpcClient.GetSimilarMovies(context.Background(), &pb.SimilarMoviesRequest{
-		MovieId: int32(movieID),
-		Limit:   5, // we want 5 recommendations
-	})
+	recs, err := svc.SimilarMovies(r.Context(), movieID, 5)
+	if err == service.ErrNotIndexed {
+		http.Error(w, "This movie isn't indexed yet; it's been queued and should be searchable again shortly.", http.StatusAccepted)
+		return
+	}
 	if err != nil {
-		http.Error(w, fmt.Sprintf("gRPC call failed: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Error fetching recommendations: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	// A logged-in search is an implicit signal of interest in movieID, fed
+	// into that user's taste vector alongside their explicit ratings.
+	if user, ok := currentUser(r); ok {
+		recordImplicitClick(user.ID, movieID)
+	}
+
 	// Build a simple results page
 	var sb strings.Builder
 	sb.WriteString("<html><head><title>Recommendations</title></head><body>")
 	sb.WriteString(fmt.Sprintf("<h2>Recommendations for '%s'</h2>", query))
 	sb.WriteString("<ul>")
-	for _, rec := range similarResp.Recommendations {
+	for _, rec := range recs {
 		sb.WriteString(fmt.Sprintf("<li>%s (Movie ID: %d)</li>", rec.Title, rec.MovieId))
 	}
 	sb.WriteString("</ul>")
@@ -300,3 +165,46 @@ func handleSearch(w http.ResponseWriter, r *http.Request) {
 
 	w.Write([]byte(sb.String()))
 }
+
+// handleAdminQueueDepth reports how many jobs are in each status, so an
+// operator can tell whether the worker is keeping up.
+func handleAdminQueueDepth(w http.ResponseWriter, r *http.Request) {
+	depth, err := jobQueue.Depth()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("reading queue depth: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"depth":  depth,
+		"paused": jobQueue.Paused(),
+	})
+}
+
+// handleAdminQueueRetry resets a failed job (given by its "id" query param)
+// back to pending.
+func handleAdminQueueRetry(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "missing id parameter", http.StatusBadRequest)
+		return
+	}
+	if err := jobQueue.Retry(id); err != nil {
+		http.Error(w, fmt.Sprintf("retrying job %s: %v", id, err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminQueuePause stops workers from leasing new jobs; in-flight jobs
+// still finish.
+func handleAdminQueuePause(w http.ResponseWriter, r *http.Request) {
+	jobQueue.Pause()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminQueueResume undoes handleAdminQueuePause.
+func handleAdminQueueResume(w http.ResponseWriter, r *http.Request) {
+	jobQueue.Resume()
+	w.WriteHeader(http.StatusNoContent)
+}
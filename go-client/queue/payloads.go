@@ -0,0 +1,36 @@
+package queue
+
+// FetchMoviePayload is the payload for a FetchMovie job: fetch a single
+// movie's TMDb details and keywords.
+type FetchMoviePayload struct {
+	MovieID int `json:"movie_id"`
+}
+
+// CastMember is a single credited cast member carried in a
+// ComputeEmbeddingPayload.
+type CastMember struct {
+	Name      string `json:"name"`
+	Character string `json:"character"`
+	Order     int    `json:"order"`
+}
+
+// ComputeEmbeddingPayload is the payload for a ComputeEmbedding job: send a
+// previously-fetched movie to the embedding service.
+type ComputeEmbeddingPayload struct {
+	MovieID             int          `json:"movie_id"`
+	Title               string       `json:"title"`
+	Overview            string       `json:"overview"`
+	Keywords            []string     `json:"keywords"`
+	Genres              []string     `json:"genres"`
+	Cast                []CastMember `json:"cast"`
+	ReleaseYear         int          `json:"release_year"`
+	RuntimeMinutes      int          `json:"runtime_minutes"`
+	OriginalLanguage    string       `json:"original_language"`
+	ProductionCountries []string     `json:"production_countries"`
+	PosterPath          string       `json:"poster_path"`
+}
+
+// RefreshTopRatedPayload is the payload for a RefreshTopRated job: walk
+// TMDb's top-rated catalog and enqueue a FetchMovie job for each ID not
+// already indexed.
+type RefreshTopRatedPayload struct{}
@@ -0,0 +1,448 @@
+// Package queue implements a durable job queue backed by BoltDB so that
+// catalog refresh work survives process restarts instead of being redone
+// from scratch on every run. Jobs are leased by worker goroutines, retried
+// with exponential backoff on failure, and can be inspected or retried
+// through the JobQueue interface exposed to the web server and the worker
+// binary alike.
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Type identifies the kind of work a Job represents.
+type Type string
+
+const (
+	FetchMovie       Type = "fetch_movie"
+	ComputeEmbedding Type = "compute_embedding"
+	RefreshTopRated  Type = "refresh_top_rated"
+)
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	Pending Status = "pending"
+	Running Status = "running"
+	Done    Status = "done"
+	Failed  Status = "failed"
+)
+
+const (
+	maxAttempts   = 8
+	baseBackoff   = 5 * time.Second
+	maxBackoff    = 30 * time.Minute
+	jobsBucket    = "jobs"
+	indexedBucket = "indexed_movies"
+
+	// leaseTTL bounds how long a job can sit Running before Lease treats
+	// its worker as dead and makes the job runnable again. Workers only
+	// hold a job for as long as a single TMDb/gRPC call takes, so this is
+	// generous headroom above that, not a heartbeat interval.
+	leaseTTL = 10 * time.Minute
+
+	// reapAfter is how long a Done or Failed job is kept around (for
+	// /admin/queue inspection and Retry) before Reap prunes it, so the
+	// bucket doesn't grow without bound over the life of a long-running
+	// queue.
+	reapAfter = 7 * 24 * time.Hour
+)
+
+// Job is a single unit of work persisted in the queue.
+type Job struct {
+	ID        string          `json:"id"`
+	Type      Type            `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    Status          `json:"status"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	NotBefore time.Time       `json:"not_before"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// JobQueue is the interface the web server and the worker binary share so
+// the persistence backend can be swapped without touching either caller.
+type JobQueue interface {
+	// Enqueue persists a new pending job of the given type.
+	Enqueue(jobType Type, payload interface{}) (*Job, error)
+	// Lease atomically claims the oldest runnable job of one of the given
+	// types, marking it Running, or returns nil if none are ready.
+	Lease(types ...Type) (*Job, error)
+	// Complete marks a leased job Done.
+	Complete(id string) error
+	// Fail records a job's failure, scheduling a retry with exponential
+	// backoff until its attempt count is exhausted, at which point it is
+	// marked Failed for good.
+	Fail(id string, cause error) error
+	// Retry resets a Failed job back to Pending so it is picked up again.
+	Retry(id string) error
+	// List returns all jobs with the given status, or every job if status
+	// is empty.
+	List(status Status) ([]*Job, error)
+	// Depth reports the number of jobs per status, for the admin endpoint.
+	Depth() (map[Status]int, error)
+	// Pause stops Lease from handing out new work; in-flight jobs finish.
+	Pause()
+	// Resume undoes Pause.
+	Resume()
+	// Paused reports whether the queue is currently paused.
+	Paused() bool
+	// MarkIndexed records that movieID has a completed embedding, so a
+	// later refresh_top_rated run knows to skip it.
+	MarkIndexed(movieID int) error
+	// IsIndexed reports whether movieID was previously passed to
+	// MarkIndexed.
+	IsIndexed(movieID int) (bool, error)
+	// Reap prunes Done and Failed jobs older than reapAfter and returns
+	// how many were removed.
+	Reap() (int, error)
+	Close() error
+}
+
+// BoltQueue is a JobQueue backed by a single BoltDB file.
+type BoltQueue struct {
+	db *bolt.DB
+
+	mu     sync.Mutex
+	paused bool
+}
+
+// Open opens (creating if necessary) a BoltDB-backed queue at path.
+func Open(path string) (*BoltQueue, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening job queue at %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(jobsBucket))
+		if err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists([]byte(indexedBucket)); err != nil {
+			return err
+		}
+		return reclaimRunning(b)
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing job queue schema: %w", err)
+	}
+	return &BoltQueue{db: db}, nil
+}
+
+// reclaimRunning resets every Running job back to Pending. Called once at
+// Open: a process restart means whatever worker held each lease is gone,
+// so without this those jobs would sit Running forever.
+func reclaimRunning(b *bolt.Bucket) error {
+	type found struct {
+		key []byte
+		job Job
+	}
+	var running []found
+	err := b.ForEach(func(k, v []byte) error {
+		var job Job
+		if err := json.Unmarshal(v, &job); err != nil {
+			return fmt.Errorf("decoding job %s: %w", k, err)
+		}
+		if job.Status == Running {
+			running = append(running, found{key: append([]byte(nil), k...), job: job})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, f := range running {
+		f.job.Status = Pending
+		f.job.NotBefore = now
+		f.job.UpdatedAt = now
+		body, err := json.Marshal(f.job)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(f.key, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *BoltQueue) Close() error {
+	return q.db.Close()
+}
+
+func (q *BoltQueue) Enqueue(jobType Type, payload interface{}) (*Job, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling payload for %s job: %w", jobType, err)
+	}
+	now := time.Now()
+	job := &Job{
+		ID:        uuid.NewString(),
+		Type:      jobType,
+		Payload:   body,
+		Status:    Pending,
+		NotBefore: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := q.put(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Lease scans for the oldest pending, runnable job matching one of the
+// given types (all types if none given) and marks it Running. Along the
+// way, any job that's been Running for longer than leaseTTL is treated as
+// abandoned by a dead worker and reset to Pending so it can be leased
+// again.
+func (q *BoltQueue) Lease(types ...Type) (*Job, error) {
+	if q.Paused() {
+		return nil, nil
+	}
+	wanted := make(map[Type]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	var leased *Job
+	err := q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(jobsBucket))
+		now := time.Now()
+
+		var best *Job
+		var bestKey []byte
+		var reclaim [][]byte
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("decoding job %s: %w", k, err)
+			}
+			if job.Status == Running && now.Sub(job.UpdatedAt) > leaseTTL {
+				reclaim = append(reclaim, append([]byte(nil), k...))
+				job.Status = Pending
+				job.NotBefore = now
+			}
+			if job.Status != Pending || job.NotBefore.After(now) {
+				continue
+			}
+			if len(wanted) > 0 && !wanted[job.Type] {
+				continue
+			}
+			if best == nil || job.CreatedAt.Before(best.CreatedAt) {
+				jobCopy := job
+				best = &jobCopy
+				bestKey = append([]byte(nil), k...)
+			}
+		}
+
+		for _, k := range reclaim {
+			if bestKey != nil && string(k) == string(bestKey) {
+				// About to be leased below; no need to also persist it as
+				// reclaimed first.
+				continue
+			}
+			raw := b.Get(k)
+			var job Job
+			if err := json.Unmarshal(raw, &job); err != nil {
+				return fmt.Errorf("decoding job %s: %w", k, err)
+			}
+			job.Status = Pending
+			job.NotBefore = now
+			job.UpdatedAt = now
+			body, err := json.Marshal(job)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(k, body); err != nil {
+				return err
+			}
+		}
+
+		if best == nil {
+			return nil
+		}
+		best.Status = Running
+		best.UpdatedAt = now
+		body, err := json.Marshal(best)
+		if err != nil {
+			return err
+		}
+		leased = best
+		return b.Put(bestKey, body)
+	})
+	return leased, err
+}
+
+func (q *BoltQueue) Complete(id string) error {
+	return q.update(id, func(job *Job) {
+		job.Status = Done
+		job.LastError = ""
+	})
+}
+
+func (q *BoltQueue) Fail(id string, cause error) error {
+	return q.update(id, func(job *Job) {
+		job.Attempts++
+		job.LastError = cause.Error()
+		if job.Attempts >= maxAttempts {
+			job.Status = Failed
+			return
+		}
+		job.Status = Pending
+		job.NotBefore = time.Now().Add(backoff(job.Attempts))
+	})
+}
+
+func (q *BoltQueue) Retry(id string) error {
+	return q.update(id, func(job *Job) {
+		job.Status = Pending
+		job.Attempts = 0
+		job.LastError = ""
+		job.NotBefore = time.Now()
+	})
+}
+
+func (q *BoltQueue) List(status Status) ([]*Job, error) {
+	var jobs []*Job
+	err := q.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(jobsBucket))
+		return b.ForEach(func(_, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			if status == "" || job.Status == status {
+				jobs = append(jobs, &job)
+			}
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+func (q *BoltQueue) Depth() (map[Status]int, error) {
+	jobs, err := q.List("")
+	if err != nil {
+		return nil, err
+	}
+	depth := map[Status]int{Pending: 0, Running: 0, Done: 0, Failed: 0}
+	for _, job := range jobs {
+		depth[job.Status]++
+	}
+	return depth, nil
+}
+
+// Reap deletes Done and Failed jobs last updated more than reapAfter ago.
+func (q *BoltQueue) Reap() (int, error) {
+	cutoff := time.Now().Add(-reapAfter)
+	var removed int
+	err := q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(jobsBucket))
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("decoding job %s: %w", k, err)
+			}
+			if (job.Status == Done || job.Status == Failed) && job.UpdatedAt.Before(cutoff) {
+				if err := c.Delete(); err != nil {
+					return fmt.Errorf("reaping job %s: %w", k, err)
+				}
+				removed++
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
+
+func (q *BoltQueue) Pause() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.paused = true
+}
+
+func (q *BoltQueue) Resume() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.paused = false
+}
+
+func (q *BoltQueue) Paused() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.paused
+}
+
+func (q *BoltQueue) MarkIndexed(movieID int) error {
+	return q.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(indexedBucket)).Put(movieIDKey(movieID), []byte(time.Now().Format(time.RFC3339)))
+	})
+}
+
+func (q *BoltQueue) IsIndexed(movieID int) (bool, error) {
+	var indexed bool
+	err := q.db.View(func(tx *bolt.Tx) error {
+		indexed = tx.Bucket([]byte(indexedBucket)).Get(movieIDKey(movieID)) != nil
+		return nil
+	})
+	return indexed, err
+}
+
+func movieIDKey(movieID int) []byte {
+	return []byte(fmt.Sprintf("%d", movieID))
+}
+
+func (q *BoltQueue) put(job *Job) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return q.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(jobsBucket)).Put([]byte(job.ID), body)
+	})
+}
+
+func (q *BoltQueue) update(id string, mutate func(*Job)) error {
+	return q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(jobsBucket))
+		raw := b.Get([]byte(id))
+		if raw == nil {
+			return fmt.Errorf("job %s not found", id)
+		}
+		var job Job
+		if err := json.Unmarshal(raw, &job); err != nil {
+			return fmt.Errorf("decoding job %s: %w", id, err)
+		}
+		mutate(&job)
+		job.UpdatedAt = time.Now()
+		body, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(id), body)
+	})
+}
+
+// backoff returns the exponential backoff delay for the given attempt
+// count, capped at maxBackoff.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff << uint(attempts-1)
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,127 @@
+// Package service holds the recommendation logic shared by the HTML and
+// JSON API surfaces, so the two stay in sync instead of each reimplementing
+// TMDb lookups, index misses, and personalization against their own copies
+// of the gRPC and job-queue clients.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "movie-recommender/go-client/pb/proto"
+	"movie-recommender/go-client/queue"
+	"movie-recommender/go-client/tmdb"
+	"movie-recommender/go-client/users"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNotIndexed is returned when a movie isn't in the embedding index yet.
+// Callers should treat this as "try again shortly", not a hard failure: a
+// FetchMovie job has already been enqueued for it.
+var ErrNotIndexed = errors.New("movie not indexed yet")
+
+// ErrNoTasteProfile is returned when a user has no taste vector yet (they
+// haven't rated enough movies). Callers should treat this as a cold-start
+// state, not a hard failure.
+var ErrNoTasteProfile = errors.New("user has no taste profile yet")
+
+// Service is the single place HTML and REST handlers go to look up movies,
+// fetch recommendations, and record feedback.
+type Service struct {
+	tmdb  *tmdb.Client
+	grpc  pb.EmbeddingServiceClient
+	jobs  queue.JobQueue
+	users users.Store
+}
+
+// New returns a Service backed by the given clients.
+func New(tmdbClient *tmdb.Client, grpcClient pb.EmbeddingServiceClient, jobQueue queue.JobQueue, userStore users.Store) *Service {
+	return &Service{tmdb: tmdbClient, grpc: grpcClient, jobs: jobQueue, users: userStore}
+}
+
+// SearchMovieID resolves a free-text title to a TMDb movie ID.
+func (s *Service) SearchMovieID(ctx context.Context, query string) (int32, error) {
+	movieID, err := s.tmdb.GetMovieID(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("looking up movie: %w", err)
+	}
+	return int32(movieID), nil
+}
+
+// Movie fetches a movie's TMDb details.
+func (s *Service) Movie(ctx context.Context, movieID int32) (tmdb.Movie, error) {
+	return s.tmdb.GetMovieInfo(ctx, int(movieID))
+}
+
+// SimilarMovies returns the nearest neighbors of movieID in the embedding
+// index. If the embedding service reports movieID isn't indexed yet (a
+// codes.NotFound status), it enqueues a FetchMovie job and returns
+// ErrNotIndexed; any other error (the service being unreachable, an
+// internal error, ...) is returned as-is so callers don't mistake an
+// outage for an index miss.
+func (s *Service) SimilarMovies(ctx context.Context, movieID int32, limit int32) ([]*pb.MovieRecommendation, error) {
+	resp, err := s.grpc.GetSimilarMovies(ctx, &pb.SimilarMoviesRequest{MovieId: movieID, Limit: limit})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return nil, fmt.Errorf("getting similar movies for %d: %w", movieID, err)
+		}
+		if _, qerr := s.jobs.Enqueue(queue.FetchMovie, queue.FetchMoviePayload{MovieID: int(movieID)}); qerr != nil {
+			return nil, fmt.Errorf("enqueueing fetch_movie for %d after index miss: %w", movieID, qerr)
+		}
+		return nil, ErrNotIndexed
+	}
+	return resp.Recommendations, nil
+}
+
+// PersonalizedRecommendations returns userID's nearest neighbors by taste
+// vector, excluding movies they've already rated. The embedding service has
+// no other way to learn what a user has rated, so their rating history
+// rides along in the request; it's what the service builds the taste-vector
+// centroid from. If the embedding service reports userID has no taste
+// vector yet (a codes.NotFound status), it returns ErrNoTasteProfile; any
+// other error (the service being unreachable, an internal error, ...) is
+// returned as-is so callers don't mistake an outage for a cold-start user.
+func (s *Service) PersonalizedRecommendations(ctx context.Context, userID int64, limit int32) ([]*pb.MovieRecommendation, error) {
+	history, err := s.users.History(userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading rating history for user %d: %w", userID, err)
+	}
+	ratings := make([]*pb.RatingEvent, 0, len(history))
+	for _, event := range history {
+		ratings = append(ratings, &pb.RatingEvent{MovieId: event.MovieID, Rating: int32(event.Rating)})
+	}
+
+	resp, err := s.grpc.GetPersonalizedRecommendations(ctx, &pb.PersonalizedRecommendationsRequest{UserId: userID, Limit: limit, Ratings: ratings})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return nil, fmt.Errorf("getting personalized recommendations for user %d: %w", userID, err)
+		}
+		return nil, ErrNoTasteProfile
+	}
+	return resp.Recommendations, nil
+}
+
+// RebuildIndex enqueues a full catalog refresh.
+func (s *Service) RebuildIndex() error {
+	_, err := s.jobs.Enqueue(queue.RefreshTopRated, queue.RefreshTopRatedPayload{})
+	return err
+}
+
+// RecordClick logs an implicit-feedback signal: userID viewed movieID's
+// recommendations.
+func (s *Service) RecordClick(userID int64, movieID int32) error {
+	return s.users.RecordRating(users.RatingEvent{UserID: userID, MovieID: movieID, Rating: users.ClickRating})
+}
+
+// RecordRating logs explicit thumbs-up/thumbs-down feedback.
+func (s *Service) RecordRating(userID int64, movieID int32, rating int) error {
+	return s.users.RecordRating(users.RatingEvent{UserID: userID, MovieID: movieID, Rating: rating})
+}
+
+// History returns userID's rating events, most recent first.
+func (s *Service) History(userID int64) ([]users.RatingEvent, error) {
+	return s.users.History(userID)
+}
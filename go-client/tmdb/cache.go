@@ -0,0 +1,18 @@
+package tmdb
+
+// Cache stores the raw bodies of TMDb API responses, keyed by endpoint and
+// movie ID, alongside the ETag they were served with. A client issues a
+// conditional request with the cached ETag and only refetches the body
+// when TMDb reports it has changed.
+type Cache interface {
+	// Get returns the cached ETag and body for (endpoint, movieID), or
+	// ok=false if nothing is cached yet.
+	Get(endpoint string, movieID int) (etag string, body []byte, ok bool)
+	// Set stores the ETag and body a response was served with.
+	Set(endpoint string, movieID int, etag string, body []byte) error
+}
+
+type cacheKey struct {
+	Endpoint string
+	MovieID  int
+}
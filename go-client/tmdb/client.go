@@ -0,0 +1,335 @@
+// Package tmdb wraps the subset of the TMDb HTTP API this project needs:
+// looking up a movie by title, fetching a movie's details, credits, and
+// keywords, and paging through the top-rated catalog. A Client rate-limits
+// requests, retries transient failures, and caches responses by ETag.
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+)
+
+// defaultRatePerSecond stays comfortably under TMDb's ~50 req/s cap.
+const defaultRatePerSecond = 45
+
+type Keyword struct {
+	ID      int    `json:"id"`
+	Keyword string `json:"name"`
+}
+
+type KeywordResponse struct {
+	ID       int       `json:"id"`
+	Keywords []Keyword `json:"keywords"`
+}
+
+type MovieSearchResponse struct {
+	Results []MovieResult `json:"results"`
+}
+
+type TopRatedResponse struct {
+	Results    []MovieResult `json:"results"`
+	TotalPages int           `json:"total_pages"`
+}
+
+type MovieResult struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+}
+
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProductionCountry struct {
+	ISO3166_1 string `json:"iso_3166_1"`
+	Name      string `json:"name"`
+}
+
+// movieDetails mirrors the fields this package reads off TMDb's
+// /movie/{id} response.
+type movieDetails struct {
+	Title               string              `json:"title"`
+	Overview            string              `json:"overview"`
+	ReleaseDate         string              `json:"release_date"`
+	Runtime             int                 `json:"runtime"`
+	OriginalLanguage    string              `json:"original_language"`
+	PosterPath          string              `json:"poster_path"`
+	Genres              []Genre             `json:"genres"`
+	ProductionCountries []ProductionCountry `json:"production_countries"`
+}
+
+// CastMember is a single credited cast member, as returned by TMDb's
+// /credits endpoint.
+type CastMember struct {
+	Name      string `json:"name"`
+	Character string `json:"character"`
+	Order     int    `json:"order"`
+}
+
+type creditsResponse struct {
+	Cast []CastMember `json:"cast"`
+}
+
+// Movie is the subset of TMDb's movie, credits, and keywords data this
+// project sends on to the embedding service and serves from
+// GET /api/v1/movies/{id}.
+type Movie struct {
+	Title               string       `json:"title"`
+	Overview            string       `json:"overview"`
+	Keywords            []string     `json:"keywords"`
+	Genres              []string     `json:"genres"`
+	Cast                []CastMember `json:"cast"`
+	ReleaseYear         int          `json:"release_year"`
+	RuntimeMinutes      int          `json:"runtime_minutes"`
+	OriginalLanguage    string       `json:"original_language"`
+	ProductionCountries []string     `json:"production_countries"`
+	PosterPath          string       `json:"poster_path"`
+}
+
+// Client talks to the TMDb API on behalf of a single API key.
+type Client struct {
+	apiKey     string
+	httpClient *http.Client
+	limiter    *limiter
+	cache      Cache
+}
+
+// Option configures a Client constructed with NewClient.
+type Option func(*Client)
+
+// WithCache gives the Client a Cache of ETag-tagged response bodies. Every
+// call still goes to TMDb, but as a conditional request with the cached
+// ETag, and a 304 reply is answered from the cached body. Without a cache,
+// every call downloads the full body.
+func WithCache(cache Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
+// WithRateLimit overrides the default requests-per-second cap.
+func WithRateLimit(perSecond float64) Option {
+	return func(c *Client) { c.limiter = newLimiter(perSecond) }
+}
+
+// NewClient returns a Client authenticated with apiKey. By default it has
+// no cache and rate-limits itself to defaultRatePerSecond requests/second.
+// Concurrency across movies comes from the caller running multiple workers
+// against the job queue, not from this package.
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		apiKey:     apiKey,
+		httpClient: http.DefaultClient,
+		limiter:    newLimiter(defaultRatePerSecond),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewClientFromEnv returns a Client using the TMDB_API_KEY environment
+// variable, matching how this project has always been configured.
+func NewClientFromEnv(opts ...Option) *Client {
+	return NewClient(os.Getenv("TMDB_API_KEY"), opts...)
+}
+
+// GetMovieID performs a search for a movie title on TMDb, and returns the
+// ID of the first result. If no results are found, it returns an error.
+func (c *Client) GetMovieID(ctx context.Context, title string) (int, error) {
+	baseURL := "https://api.themoviedb.org/3/search/movie"
+	query := url.QueryEscape(title)
+	apiURL := fmt.Sprintf("%s?api_key=%s&query=%s", baseURL, c.apiKey, query)
+
+	var searchResponse MovieSearchResponse
+	if err := c.getJSON(ctx, "search", searchCacheKey(title), apiURL, &searchResponse); err != nil {
+		return 0, err
+	}
+	if len(searchResponse.Results) == 0 {
+		return 0, fmt.Errorf("no results found for movie title: %s", title)
+	}
+	return searchResponse.Results[0].ID, nil
+}
+
+// searchCacheKey derives a cache slot for a search query from its title.
+// getJSON's cache is keyed by (endpoint, movieID), but a search query has
+// no movie ID yet; hashing the title instead of passing a constant keeps
+// distinct queries from colliding on the same cache entry.
+func searchCacheKey(title string) int {
+	h := fnv.New32a()
+	h.Write([]byte(title))
+	return int(h.Sum32())
+}
+
+// getJSON fetches url, rate-limiting, retrying, and caching (keyed by
+// endpoint and movieID) along the way, and unmarshals the result into
+// target.
+func (c *Client) getJSON(ctx context.Context, endpoint string, movieID int, url string, target interface{}) error {
+	var etag string
+	var cachedBody []byte
+	if c.cache != nil {
+		etag, cachedBody, _ = c.cache.Get(endpoint, movieID)
+	}
+
+	var body []byte
+	err := withRetry(ctx, func() error {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Add("accept", "application/json")
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode == http.StatusNotModified && cachedBody != nil {
+			body = cachedBody
+			return nil
+		}
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+			return &retryableStatus{status: res.StatusCode, after: parseRetryAfter(res.Header.Get("Retry-After"))}
+		}
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %d fetching %s", res.StatusCode, url)
+		}
+
+		data, err := io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		body = data
+
+		if newETag := res.Header.Get("Etag"); c.cache != nil && newETag != "" {
+			if err := c.cache.Set(endpoint, movieID, newETag, data); err != nil {
+				log.Printf("tmdb: caching %s/%d: %v", endpoint, movieID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
+}
+
+// GetMovieInfo fetches a movie's details, credits, and keywords from TMDb
+// and assembles them into a Movie ready to hand to the embedding service.
+// Unchanged responses are served from cache rather than refetched.
+func (c *Client) GetMovieInfo(ctx context.Context, movieID int) (Movie, error) {
+	var details movieDetails
+	movieURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d?api_key=%s", movieID, c.apiKey)
+	if err := c.getJSON(ctx, "movie", movieID, movieURL, &details); err != nil {
+		return Movie{}, err
+	}
+
+	var keywordResp KeywordResponse
+	keywordURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d/keywords?api_key=%s", movieID, c.apiKey)
+	if err := c.getJSON(ctx, "keywords", movieID, keywordURL, &keywordResp); err != nil {
+		return Movie{}, err
+	}
+
+	var credits creditsResponse
+	creditsURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d/credits?api_key=%s", movieID, c.apiKey)
+	if err := c.getJSON(ctx, "credits", movieID, creditsURL, &credits); err != nil {
+		return Movie{}, err
+	}
+
+	// Keep only the top 5 keywords and the top 10 billed cast members; the
+	// embedding service doesn't need the long tail of either list.
+	topCount := 5
+	if len(keywordResp.Keywords) < topCount {
+		topCount = len(keywordResp.Keywords)
+	}
+	keywords := make([]string, 0, topCount)
+	for i := 0; i < topCount; i++ {
+		keywords = append(keywords, keywordResp.Keywords[i].Keyword)
+	}
+
+	castCount := 10
+	if len(credits.Cast) < castCount {
+		castCount = len(credits.Cast)
+	}
+
+	genres := make([]string, 0, len(details.Genres))
+	for _, g := range details.Genres {
+		genres = append(genres, g.Name)
+	}
+
+	countries := make([]string, 0, len(details.ProductionCountries))
+	for _, pc := range details.ProductionCountries {
+		countries = append(countries, pc.Name)
+	}
+
+	return Movie{
+		Title:               details.Title,
+		Overview:            details.Overview,
+		Keywords:            keywords,
+		Genres:              genres,
+		Cast:                credits.Cast[:castCount],
+		ReleaseYear:         releaseYear(details.ReleaseDate),
+		RuntimeMinutes:      details.Runtime,
+		OriginalLanguage:    details.OriginalLanguage,
+		ProductionCountries: countries,
+		PosterPath:          details.PosterPath,
+	}, nil
+}
+
+// releaseYear extracts the year from a TMDb "YYYY-MM-DD" release date,
+// returning 0 if it can't be parsed.
+func releaseYear(releaseDate string) int {
+	if len(releaseDate) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(releaseDate[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// GetTopRatedMovies pages through TMDb's top-rated catalog and returns the
+// full list of movie IDs.
+func (c *Client) GetTopRatedMovies(ctx context.Context) ([]int, error) {
+	movieURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/top_rated?api_key=%s", c.apiKey)
+	var topRated TopRatedResponse
+	if err := c.getJSON(ctx, "top_rated", 0, movieURL, &topRated); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Total Pages: %d", topRated.TotalPages)
+	allMovieIDs := []int{}
+	for _, movie := range topRated.Results {
+		allMovieIDs = append(allMovieIDs, movie.ID)
+	}
+
+	for page := 2; page <= topRated.TotalPages; page++ {
+		pageURL := fmt.Sprintf("https://api.themoviedb.org/3/movie/top_rated?api_key=%s&page=%d", c.apiKey, page)
+		var pageResp TopRatedResponse
+		if err := c.getJSON(ctx, "top_rated", page, pageURL, &pageResp); err != nil {
+			return allMovieIDs, fmt.Errorf("fetching page %d: %w", page, err)
+		}
+		for _, movie := range pageResp.Results {
+			allMovieIDs = append(allMovieIDs, movie.ID)
+		}
+	}
+
+	return allMovieIDs, nil
+}
@@ -0,0 +1,53 @@
+package tmdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type diskEntry struct {
+	ETag string `json:"etag"`
+	Body []byte `json:"body"`
+}
+
+// DiskCache is an on-disk Cache that keeps one JSON file per
+// (endpoint, movie ID) pair under Dir, so cached TMDb responses survive a
+// process restart.
+type DiskCache struct {
+	Dir string
+}
+
+// NewDiskCache returns a DiskCache rooted at dir. The directory is created
+// lazily on the first Set.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{Dir: dir}
+}
+
+func (c *DiskCache) path(endpoint string, movieID int) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("%s_%d.json", endpoint, movieID))
+}
+
+func (c *DiskCache) Get(endpoint string, movieID int) (string, []byte, bool) {
+	data, err := os.ReadFile(c.path(endpoint, movieID))
+	if err != nil {
+		return "", nil, false
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", nil, false
+	}
+	return entry.ETag, entry.Body, true
+}
+
+func (c *DiskCache) Set(endpoint string, movieID int, etag string, body []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("creating cache dir %s: %w", c.Dir, err)
+	}
+	data, err := json.Marshal(diskEntry{ETag: etag, Body: body})
+	if err != nil {
+		return fmt.Errorf("encoding cache entry for %s/%d: %w", endpoint, movieID, err)
+	}
+	return os.WriteFile(c.path(endpoint, movieID), data, 0o644)
+}
@@ -0,0 +1,58 @@
+package tmdb
+
+import "testing"
+
+func TestDiskCacheGetSetMiss(t *testing.T) {
+	c := NewDiskCache(t.TempDir())
+
+	if _, _, ok := c.Get("movie", 1); ok {
+		t.Fatalf("Get on empty cache returned ok=true, want false")
+	}
+
+	if err := c.Set("movie", 1, "etag1", []byte("body1")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	etag, body, ok := c.Get("movie", 1)
+	if !ok || etag != "etag1" || string(body) != "body1" {
+		t.Fatalf("Get(movie, 1) = %q, %q, %v, want etag1, body1, true", etag, body, ok)
+	}
+}
+
+func TestDiskCacheSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewDiskCache(dir).Set("movie", 1, "etag1", []byte("body1")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// A fresh DiskCache pointed at the same directory should see entries
+	// written by a prior instance, since that's the point of a disk cache.
+	c2 := NewDiskCache(dir)
+	etag, body, ok := c2.Get("movie", 1)
+	if !ok || etag != "etag1" || string(body) != "body1" {
+		t.Fatalf("Get(movie, 1) on reopened cache = %q, %q, %v, want etag1, body1, true", etag, body, ok)
+	}
+}
+
+func TestDiskCacheCreatesDirLazily(t *testing.T) {
+	dir := t.TempDir() + "/nested/cache-dir"
+	c := NewDiskCache(dir)
+
+	if err := c.Set("movie", 1, "etag1", []byte("body1")); err != nil {
+		t.Fatalf("Set on non-existent dir: %v", err)
+	}
+	if _, _, ok := c.Get("movie", 1); !ok {
+		t.Fatalf("Get after Set on lazily-created dir = miss, want hit")
+	}
+}
+
+func TestDiskCacheDistinctEndpointsDoNotCollide(t *testing.T) {
+	c := NewDiskCache(t.TempDir())
+
+	c.Set("movie", 1, "movie-etag", []byte("movie-body"))
+	c.Set("credits", 1, "credits-etag", []byte("credits-body"))
+
+	etag, _, ok := c.Get("credits", 1)
+	if !ok || etag != "credits-etag" {
+		t.Fatalf("Get(credits, 1) = %q, %v, want credits-etag, true", etag, ok)
+	}
+}
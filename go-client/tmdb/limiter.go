@@ -0,0 +1,50 @@
+package tmdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter is a token-bucket rate limiter used to keep requests under TMDb's
+// published rate cap (~50 req/s) even when many goroutines are fetching
+// concurrently.
+type limiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	max      float64
+	rate     float64 // tokens added per second
+	lastFill time.Time
+}
+
+func newLimiter(ratePerSecond float64) *limiter {
+	return &limiter{
+		tokens:   ratePerSecond,
+		max:      ratePerSecond,
+		rate:     ratePerSecond,
+		lastFill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *limiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.max, l.tokens+now.Sub(l.lastFill).Seconds()*l.rate)
+		l.lastFill = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package tmdb
+
+import (
+	"container/list"
+	"sync"
+)
+
+type memoryEntry struct {
+	key  cacheKey
+	etag string
+	body []byte
+}
+
+// MemoryCache is an in-memory, LRU-evicted Cache. It's cheap to construct
+// and well suited to short-lived processes or tests that don't need a
+// cache to survive a restart; use DiskCache for that.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[cacheKey]*list.Element
+	order    *list.List
+}
+
+// NewMemoryCache returns a MemoryCache that holds at most capacity entries.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		items:    make(map[cacheKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *MemoryCache) Get(endpoint string, movieID int) (string, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[cacheKey{endpoint, movieID}]
+	if !ok {
+		return "", nil, false
+	}
+	c.order.MoveToFront(el)
+	entry := el.Value.(*memoryEntry)
+	return entry.etag, entry.body, true
+}
+
+func (c *MemoryCache) Set(endpoint string, movieID int, etag string, body []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey{endpoint, movieID}
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.etag, entry.body = etag, body
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	el := c.order.PushFront(&memoryEntry{key: key, etag: etag, body: body})
+	c.items[key] = el
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
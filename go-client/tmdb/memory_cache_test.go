@@ -0,0 +1,63 @@
+package tmdb
+
+import "testing"
+
+func TestMemoryCacheGetSetMiss(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	if _, _, ok := c.Get("movie", 1); ok {
+		t.Fatalf("Get on empty cache returned ok=true, want false")
+	}
+
+	if err := c.Set("movie", 1, "etag1", []byte("body1")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	etag, body, ok := c.Get("movie", 1)
+	if !ok || etag != "etag1" || string(body) != "body1" {
+		t.Fatalf("Get(movie, 1) = %q, %q, %v, want etag1, body1, true", etag, body, ok)
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set("movie", 1, "e1", []byte("b1"))
+	c.Set("movie", 2, "e2", []byte("b2"))
+	// Touch movie 1 so movie 2 becomes the least recently used entry.
+	c.Get("movie", 1)
+	c.Set("movie", 3, "e3", []byte("b3"))
+
+	if _, _, ok := c.Get("movie", 2); ok {
+		t.Fatalf("Get(movie, 2) = ok, want evicted as least recently used")
+	}
+	if _, _, ok := c.Get("movie", 1); !ok {
+		t.Fatalf("Get(movie, 1) = miss, want still cached (was touched before eviction)")
+	}
+	if _, _, ok := c.Get("movie", 3); !ok {
+		t.Fatalf("Get(movie, 3) = miss, want cached (just inserted)")
+	}
+}
+
+func TestMemoryCacheSetOverwritesExisting(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set("movie", 1, "old", []byte("old-body"))
+	c.Set("movie", 1, "new", []byte("new-body"))
+
+	etag, body, ok := c.Get("movie", 1)
+	if !ok || etag != "new" || string(body) != "new-body" {
+		t.Fatalf("Get(movie, 1) = %q, %q, %v, want new, new-body, true", etag, body, ok)
+	}
+}
+
+func TestMemoryCacheDistinctEndpointsDoNotCollide(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set("movie", 1, "movie-etag", []byte("movie-body"))
+	c.Set("credits", 1, "credits-etag", []byte("credits-body"))
+
+	etag, _, ok := c.Get("credits", 1)
+	if !ok || etag != "credits-etag" {
+		t.Fatalf("Get(credits, 1) = %q, %v, want credits-etag, true", etag, ok)
+	}
+}
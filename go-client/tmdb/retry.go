@@ -0,0 +1,69 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const maxRetries = 5
+
+// retryableStatus signals that a request failed with a transient TMDb
+// status (429 or 5xx) and carries the Retry-After delay, if any, that
+// withRetry should honor before trying again.
+type retryableStatus struct {
+	status int
+	after  time.Duration
+}
+
+func (e *retryableStatus) Error() string {
+	return fmt.Sprintf("tmdb returned status %d", e.status)
+}
+
+// withRetry runs fn, retrying with exponential backoff (or the server's
+// Retry-After, when given) on a retryableStatus error, up to maxRetries
+// attempts.
+func withRetry(ctx context.Context, fn func() error) error {
+	backoff := 500 * time.Millisecond
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		var rs *retryableStatus
+		if !errors.As(err, &rs) {
+			return err
+		}
+		if attempt == maxRetries {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		wait := rs.after
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// parseRetryAfter parses an HTTP Retry-After header given in seconds,
+// returning 0 if it's absent or malformed.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
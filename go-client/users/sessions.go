@@ -0,0 +1,69 @@
+package users
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// sessionTTL is how long a session stays valid after it's created; using
+// it doesn't extend it.
+const sessionTTL = 30 * 24 * time.Hour
+
+// SessionStore maps opaque session tokens to user IDs, so handlers can
+// recognize a returning user from their cookie alone. It's in-memory; a
+// process restart signs everyone out.
+type SessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]session
+}
+
+type session struct {
+	userID    int64
+	expiresAt time.Time
+}
+
+// NewSessionStore returns an empty SessionStore.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{sessions: make(map[string]session)}
+}
+
+// Create starts a new session for userID and returns its token.
+func (s *SessionStore) Create(userID int64) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[token] = session{userID: userID, expiresAt: time.Now().Add(sessionTTL)}
+	return token, nil
+}
+
+// Lookup returns the user ID for token, or ok=false if the token is
+// missing or expired.
+func (s *SessionStore) Lookup(token string) (userID int64, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, found := s.sessions[token]
+	if !found || time.Now().After(sess.expiresAt) {
+		return 0, false
+	}
+	return sess.userID, true
+}
+
+// Delete ends a session, e.g. on logout.
+func (s *SessionStore) Delete(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, token)
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,174 @@
+// Package users implements accounts and rating history so recommendations
+// can be personalized per user instead of depending only on the movie in
+// the search box. A Store holds users and their (user, movie, rating)
+// events in SQLite; the embedding service turns that history into a taste
+// vector via GetPersonalizedRecommendations.
+package users
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Rating values recorded against a (user, movie) pair. ClickRating is the
+// implicit signal from opening a search result; ThumbsUp and ThumbsDown are
+// explicit feedback on a recommendation.
+const (
+	ClickRating      = 0
+	ThumbsUpRating   = 1
+	ThumbsDownRating = -1
+)
+
+// User is a registered account.
+type User struct {
+	ID           int64
+	Email        string
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+// RatingEvent is a single (user, movie, rating) observation, explicit or
+// implicit, used to build that user's taste vector.
+type RatingEvent struct {
+	UserID    int64
+	MovieID   int32
+	Rating    int
+	CreatedAt time.Time
+}
+
+// Store is the persistence interface the web server depends on, so the
+// backend can be swapped without touching callers.
+type Store interface {
+	// CreateUser registers a new account. It returns an error if email is
+	// already taken.
+	CreateUser(email, passwordHash string) (*User, error)
+	// UserByEmail looks up a user for login, or returns sql.ErrNoRows if
+	// none exists.
+	UserByEmail(email string) (*User, error)
+	// UserByID looks up a user by ID, or returns sql.ErrNoRows if none
+	// exists.
+	UserByID(id int64) (*User, error)
+	// RecordRating appends a rating event to a user's history.
+	RecordRating(event RatingEvent) error
+	// History returns a user's rating events, most recent first.
+	History(userID int64) ([]RatingEvent, error)
+	Close() error
+}
+
+// SQLiteStore is a Store backed by a single SQLite database file.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// Open opens (creating and migrating if necessary) a SQLite-backed store
+// at path.
+func Open(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening user store at %s: %w", path, err)
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating user store schema: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS users (
+			id            INTEGER PRIMARY KEY AUTOINCREMENT,
+			email         TEXT NOT NULL UNIQUE,
+			password_hash TEXT NOT NULL,
+			created_at    DATETIME NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS ratings (
+			user_id    INTEGER NOT NULL,
+			movie_id   INTEGER NOT NULL,
+			rating     INTEGER NOT NULL,
+			created_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS ratings_user_id_idx ON ratings (user_id);
+	`)
+	return err
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) CreateUser(email, passwordHash string) (*User, error) {
+	now := time.Now()
+	res, err := s.db.Exec(
+		`INSERT INTO users (email, password_hash, created_at) VALUES (?, ?, ?)`,
+		email, passwordHash, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating user %s: %w", email, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("reading new user id for %s: %w", email, err)
+	}
+	return &User{ID: id, Email: email, PasswordHash: passwordHash, CreatedAt: now}, nil
+}
+
+func (s *SQLiteStore) UserByEmail(email string) (*User, error) {
+	row := s.db.QueryRow(
+		`SELECT id, email, password_hash, created_at FROM users WHERE email = ?`, email,
+	)
+	return scanUser(row)
+}
+
+func (s *SQLiteStore) UserByID(id int64) (*User, error) {
+	row := s.db.QueryRow(
+		`SELECT id, email, password_hash, created_at FROM users WHERE id = ?`, id,
+	)
+	return scanUser(row)
+}
+
+func scanUser(row *sql.Row) (*User, error) {
+	var u User
+	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *SQLiteStore) RecordRating(event RatingEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	_, err := s.db.Exec(
+		`INSERT INTO ratings (user_id, movie_id, rating, created_at) VALUES (?, ?, ?, ?)`,
+		event.UserID, event.MovieID, event.Rating, event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("recording rating for user %d, movie %d: %w", event.UserID, event.MovieID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) History(userID int64) ([]RatingEvent, error) {
+	rows, err := s.db.Query(
+		`SELECT user_id, movie_id, rating, created_at FROM ratings WHERE user_id = ? ORDER BY created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reading history for user %d: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var events []RatingEvent
+	for rows.Next() {
+		var e RatingEvent
+		if err := rows.Scan(&e.UserID, &e.MovieID, &e.Rating, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning history row for user %d: %w", userID, err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}